@@ -0,0 +1,134 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Account identifies a single key file tracked by an AccountCache.
+type Account struct {
+	Address   string
+	ChainType string
+	Path      string
+}
+
+// WalletEventKind describes what happened to an Account in a WalletEvent.
+type WalletEventKind int
+
+const (
+	// AccountArrived is sent when a key file is created or first discovered.
+	AccountArrived WalletEventKind = iota
+	// AccountDropped is sent when a key file is deleted or becomes unreadable.
+	AccountDropped
+)
+
+// WalletEvent is delivered to AccountCache subscribers whenever the account set changes.
+type WalletEvent struct {
+	Account Account
+	Kind    WalletEventKind
+}
+
+// AccountCache indexes the key files found under a keystore directory by address, and
+// keeps that index in sync with the filesystem via an fsnotify watcher. This mirrors
+// go-ethereum's accountCache/watcher split: it lets long-running relayer processes notice
+// keys dropped in (or removed) on disk without needing a restart or a blocking os.Stat
+// per lookup.
+type AccountCache struct {
+	keydir string
+
+	mu      sync.Mutex
+	byAddr  map[string]Account
+	watcher *watcher
+	notify  []chan<- WalletEvent
+}
+
+// NewAccountCache creates an AccountCache rooted at path and performs an initial scan.
+// Call Close to stop its filesystem watcher.
+func NewAccountCache(path string) *AccountCache {
+	ac := &AccountCache{
+		keydir: path,
+		byAddr: make(map[string]Account),
+	}
+	ac.watcher = newWatcher(ac)
+	ac.reload()
+	ac.watcher.start()
+	return ac
+}
+
+// Accounts returns a snapshot of all accounts currently known to the cache, sorted by address.
+func (ac *AccountCache) Accounts() []Account {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	accts := make([]Account, 0, len(ac.byAddr))
+	for _, a := range ac.byAddr {
+		accts = append(accts, a)
+	}
+	sort.Slice(accts, func(i, j int) bool { return accts[i].Address < accts[j].Address })
+	return accts
+}
+
+// Find returns the account for addr, or an error if no key file is known for it.
+func (ac *AccountCache) Find(addr string) (Account, error) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	a, ok := ac.byAddr[addr]
+	if !ok {
+		return Account{}, fmt.Errorf("no key file found for address %s in %s", addr, ac.keydir)
+	}
+	return a, nil
+}
+
+// Subscribe registers ch to receive WalletEvents as accounts arrive or are dropped. ch is
+// never closed by the cache; callers are expected to keep consuming it for the cache's lifetime.
+// Sends never block: an event that ch is not ready to receive is dropped for this subscriber.
+func (ac *AccountCache) Subscribe(ch chan<- WalletEvent) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	ac.notify = append(ac.notify, ch)
+}
+
+// Close stops the underlying filesystem watcher.
+func (ac *AccountCache) Close() {
+	ac.watcher.close()
+}
+
+// reload rescans the keystore directory and diffs the result against the current index,
+// emitting WalletEvents for anything that arrived or was dropped.
+func (ac *AccountCache) reload() {
+	found, err := scanAccounts(ac.keydir)
+	if err != nil {
+		return
+	}
+
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	for addr, acct := range found {
+		if _, ok := ac.byAddr[addr]; !ok {
+			ac.byAddr[addr] = acct
+			ac.emitLocked(WalletEvent{Account: acct, Kind: AccountArrived})
+		}
+	}
+	for addr, acct := range ac.byAddr {
+		if _, ok := found[addr]; !ok {
+			delete(ac.byAddr, addr)
+			ac.emitLocked(WalletEvent{Account: acct, Kind: AccountDropped})
+		}
+	}
+}
+
+// emitLocked sends ev to every subscriber. The caller must hold ac.mu.
+func (ac *AccountCache) emitLocked(ev WalletEvent) {
+	for _, ch := range ac.notify {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestAccountCacheFindsKeyFilesInDir(t *testing.T) {
+	dir := t.TempDir()
+	addr := "0xabc"
+	content := `{"address":"abc","crypto":{"cipher":"aes-128-ctr"},"id":"test","version":3,"type":"secp256k1"}`
+	if err := ioutil.WriteFile(filepath.Join(dir, addr+".key"), []byte(content), 0600); err != nil {
+		t.Fatalf("could not write key file: %s", err)
+	}
+
+	ac := NewAccountCache(dir)
+	defer ac.Close()
+
+	acct, err := ac.Find(addr)
+	if err != nil {
+		t.Fatalf("Find returned error: %s", err)
+	}
+	if acct.Address != addr {
+		t.Fatalf("Find.Address = %q, want %q", acct.Address, addr)
+	}
+	if acct.ChainType != "ethereum" {
+		t.Fatalf("Find.ChainType = %q, want %q", acct.ChainType, "ethereum")
+	}
+
+	accts := ac.Accounts()
+	if len(accts) != 1 || accts[0].Address != addr {
+		t.Fatalf("Accounts() = %v, want [%s]", accts, addr)
+	}
+
+	if _, err := ac.Find("0xnope"); err == nil {
+		t.Fatal("expected an error for an unknown address, got nil")
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/ChainSafe/chainbridge-utils/crypto"
+	"github.com/awnumar/memguard"
+)
+
+// KeyStore abstracts over where and how a chain's signing keys are stored, following the
+// same intent as Hyperledger Fabric's BCCSP keystore: callers never touch raw key material
+// directly, they ask the store for a Keypair and the store decides whether that means
+// reading a file off disk or asking a remote HSM/KMS to sign on the key's behalf.
+type KeyStore interface {
+	// GetKey returns the keypair for addr/chainType, along with the decrypted private key
+	// enclave for native-format key files read by the file backend. The enclave is nil for
+	// remote-signing backends, for the in-memory backend and for Web3 v3 key files.
+	//
+	// Signing contract: crypto.Keypair.Sign always receives the final digest to be signed
+	// (e.g. the Keccak256 hash of an ethereum transaction), never the unhashed message.
+	// Remote-signing backends never see which hash function a caller used upstream, so this
+	// holds for every backend below, including the KMS/Vault ones: they treat msg as an
+	// opaque already-hashed value and return a raw, chain-appropriate signature over it.
+	GetKey(addr, chainType string) (crypto.Keypair, *memguard.Enclave, error)
+	// StoreKey persists kp under addr/chainType. Backends that cannot accept new key
+	// material (e.g. a KMS alias provisioned out-of-band) return an error.
+	StoreKey(addr, chainType string, kp crypto.Keypair) error
+	// ListKeys returns the addresses known to the store.
+	ListKeys() ([]string, error)
+	// DeleteKey removes the key for addr/chainType from the store.
+	DeleteKey(addr, chainType string) error
+	// ReadOnly reports whether the backend rejects both StoreKey and DeleteKey. A false
+	// result only guarantees DeleteKey: the file and vault backends still reject StoreKey.
+	ReadOnly() bool
+}
+
+// NewKeyStore builds a KeyStore from a URL whose scheme selects the backend:
+//
+//	file:///path/to/keys     on-disk keystore directory (the historical behaviour)
+//	mem://                   in-memory keystore, intended for tests
+//	awskms://alias/<name>    AWS KMS, signing via the named key alias
+//	gcpkms://<key-path>      GCP Cloud KMS, signing via the given key resource path
+//	vault://<addr>/<path>    HashiCorp Vault transit engine, signing via the given mount path
+//
+// The file backend built this way prompts on the TTY for each key's password; use
+// NewKeyStoreWithPasswordSource under systemd/Kubernetes, where there is no TTY to prompt on.
+func NewKeyStore(rawURL string) (KeyStore, error) {
+	return NewKeyStoreWithPasswordSource(rawURL, interactivePasswordSource{})
+}
+
+// NewKeyStoreWithPasswordSource is NewKeyStore with the file backend's password supplied by
+// src instead of an interactive prompt. src is ignored by backends that don't decrypt a local
+// key file (mem, awskms, gcpkms, vault all sign without ever needing a passphrase).
+func NewKeyStoreWithPasswordSource(rawURL string, src PasswordSource) (KeyStore, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore URL %s: %w", rawURL, err)
+	}
+
+	switch u.Scheme {
+	case "file", "":
+		return newFileKeyStore(u.Path, src), nil
+	case "mem":
+		return newMemoryKeyStore(), nil
+	case "awskms":
+		return newAWSKMSKeyStore(u)
+	case "gcpkms":
+		return newGCPKMSKeyStore(u)
+	case "vault":
+		return newVaultKeyStore(u)
+	default:
+		return nil, fmt.Errorf("unknown keystore backend scheme: %s", u.Scheme)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func derEncodeRS(rsv []byte) ([]byte, error) {
+	return asn1.Marshal(derEcdsaSig{
+		R: new(big.Int).SetBytes(rsv[0:32]),
+		S: new(big.Int).SetBytes(rsv[32:64]),
+	})
+}
+
+func TestDerEcdsaSigToRSV(t *testing.T) {
+	priv, err := ethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey returned error: %s", err)
+	}
+	addr := ethcrypto.PubkeyToAddress(priv.PublicKey).Hex()
+
+	digest := make([]byte, 32)
+	for i := range digest {
+		digest[i] = byte(i)
+	}
+	rsv, err := ethcrypto.Sign(digest, priv)
+	if err != nil {
+		t.Fatalf("Sign returned error: %s", err)
+	}
+	der, err := derEncodeRS(rsv)
+	if err != nil {
+		t.Fatalf("could not DER-encode test signature: %s", err)
+	}
+
+	got, err := derEcdsaSigToRSV(der, digest, addr)
+	if err != nil {
+		t.Fatalf("derEcdsaSigToRSV returned error: %s", err)
+	}
+
+	pub, err := ethcrypto.SigToPub(digest, got)
+	if err != nil {
+		t.Fatalf("SigToPub returned error: %s", err)
+	}
+	if recovered := ethcrypto.PubkeyToAddress(*pub).Hex(); recovered != addr {
+		t.Fatalf("recovered address %s, want %s", recovered, addr)
+	}
+}
+
+func TestDerEcdsaSigToRSV_WrongAddress(t *testing.T) {
+	priv, err := ethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey returned error: %s", err)
+	}
+	other, err := ethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey returned error: %s", err)
+	}
+	otherAddr := ethcrypto.PubkeyToAddress(other.PublicKey).Hex()
+
+	digest := make([]byte, 32)
+	rsv, err := ethcrypto.Sign(digest, priv)
+	if err != nil {
+		t.Fatalf("Sign returned error: %s", err)
+	}
+	der, err := derEncodeRS(rsv)
+	if err != nil {
+		t.Fatalf("could not DER-encode test signature: %s", err)
+	}
+
+	if _, err := derEcdsaSigToRSV(der, digest, otherAddr); err == nil {
+		t.Fatal("expected an error when the signature doesn't recover to expectedAddr, got nil")
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ChainSafe/chainbridge-utils/crypto"
+	"github.com/awnumar/memguard"
+)
+
+// fileKeyStore is the original on-disk backend: each key is a "<address>.key" file inside dir.
+type fileKeyStore struct {
+	dir string
+	src PasswordSource
+}
+
+func newFileKeyStore(dir string, src PasswordSource) *fileKeyStore {
+	if src == nil {
+		src = interactivePasswordSource{}
+	}
+	return &fileKeyStore{dir: dir, src: src}
+}
+
+func (s *fileKeyStore) GetKey(addr, chainType string) (crypto.Keypair, *memguard.Enclave, error) {
+	return KeypairFromAddressWithPassword(addr, chainType, s.dir, s.src)
+}
+
+func (s *fileKeyStore) StoreKey(addr, chainType string, kp crypto.Keypair) error {
+	return fmt.Errorf("file keystore does not support StoreKey; encrypt and write the .key file directly")
+}
+
+func (s *fileKeyStore) ListKeys() ([]string, error) {
+	entries, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".key") {
+			continue
+		}
+		addrs = append(addrs, strings.TrimSuffix(e.Name(), ".key"))
+	}
+	return addrs, nil
+}
+
+func (s *fileKeyStore) DeleteKey(addr, chainType string) error {
+	return os.Remove(filepath.Join(s.dir, addr+".key"))
+}
+
+func (s *fileKeyStore) ReadOnly() bool {
+	return false
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	kmspb "cloud.google.com/go/kms/apiv1"
+	kmspbv1 "google.golang.org/genproto/googleapis/cloud/kms/v1"
+
+	"github.com/ChainSafe/chainbridge-utils/crypto"
+	"github.com/awnumar/memguard"
+)
+
+// gcpKMSKeyStore signs with a key version held in GCP Cloud KMS, identified by its full
+// resource path (e.g. "gcpkms://projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1").
+type gcpKMSKeyStore struct {
+	client     *kmspb.KeyManagementClient
+	keyVersion string
+}
+
+func newGCPKMSKeyStore(u *url.URL) (*gcpKMSKeyStore, error) {
+	keyVersion := strings.TrimPrefix(u.Host+u.Path, "/")
+	if keyVersion == "" {
+		return nil, fmt.Errorf("gcpkms: missing key resource path in URL %s", u.String())
+	}
+
+	client, err := kmspb.NewKeyManagementClient(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("gcpkms: failed to create KMS client: %w", err)
+	}
+	return &gcpKMSKeyStore{client: client, keyVersion: keyVersion}, nil
+}
+
+func (s *gcpKMSKeyStore) GetKey(addr, chainType string) (crypto.Keypair, *memguard.Enclave, error) {
+	kp := &remoteKeypair{
+		provider: "gcpkms",
+		address:  addr,
+		keyType:  keyMapping[chainType],
+		sign: func(msg []byte) ([]byte, error) {
+			// Per the KeyStore.GetKey contract, msg already IS the digest to sign (e.g.
+			// Keccak256 for ethereum) -- it must not be re-hashed here. The cryptoKeyVersion
+			// behind s.keyVersion must be provisioned with a signing algorithm whose digest
+			// length matches len(msg) (32 bytes for Keccak256/SHA-256), since GCP's API
+			// requires tagging which digest field the bytes go in rather than accepting a
+			// bare digest.
+			if len(msg) != 32 {
+				return nil, fmt.Errorf("gcpkms: expected a 32-byte digest, got %d bytes", len(msg))
+			}
+			resp, err := s.client.AsymmetricSign(context.Background(), &kmspbv1.AsymmetricSignRequest{
+				Name: s.keyVersion,
+				Digest: &kmspbv1.Digest{
+					Digest: &kmspbv1.Digest_Sha256{Sha256: msg},
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("gcpkms: sign failed: %w", err)
+			}
+			// Cloud KMS returns a DER-encoded ECDSA signature with no recovery id, same as
+			// AWS KMS; convert it the same way so every remote-signing backend honors the
+			// "raw, chain-appropriate signature" contract from KeyStore.GetKey's doc comment.
+			return derEcdsaSigToRSV(resp.Signature, msg, addr)
+		},
+	}
+	return kp, nil, nil
+}
+
+func (s *gcpKMSKeyStore) StoreKey(addr, chainType string, kp crypto.Keypair) error {
+	return fmt.Errorf("gcpkms: keys must be provisioned in Cloud KMS out-of-band, StoreKey is unsupported")
+}
+
+func (s *gcpKMSKeyStore) ListKeys() ([]string, error) {
+	return nil, fmt.Errorf("gcpkms: ListKeys is unsupported, list key versions directly via the KMS API")
+}
+
+func (s *gcpKMSKeyStore) DeleteKey(addr, chainType string) error {
+	return fmt.Errorf("gcpkms: key version destruction must go through the Cloud KMS API with its scheduled deletion window")
+}
+
+func (s *gcpKMSKeyStore) ReadOnly() bool {
+	return true
+}
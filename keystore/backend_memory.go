@@ -0,0 +1,74 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/chainbridge-utils/crypto"
+	"github.com/awnumar/memguard"
+)
+
+// memoryEntry pairs a stored keypair with the bare address it was stored under, so ListKeys
+// can report addresses without having to reverse-parse the internal composite map key.
+type memoryEntry struct {
+	addr string
+	kp   crypto.Keypair
+}
+
+// memoryKeyStore holds keypairs purely in memory. It never touches disk, so it is intended
+// for tests and for backends (e.g. insecureKeypairFromAddress) that build keys on the fly.
+type memoryKeyStore struct {
+	mu   sync.RWMutex
+	keys map[string]memoryEntry
+}
+
+func newMemoryKeyStore() *memoryKeyStore {
+	return &memoryKeyStore{keys: make(map[string]memoryEntry)}
+}
+
+func (s *memoryKeyStore) GetKey(addr, chainType string) (crypto.Keypair, *memguard.Enclave, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.keys[memoryKeyID(addr, chainType)]
+	if !ok {
+		return nil, nil, fmt.Errorf("no key found for address %s", addr)
+	}
+	return entry.kp, nil, nil
+}
+
+func (s *memoryKeyStore) StoreKey(addr, chainType string, kp crypto.Keypair) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[memoryKeyID(addr, chainType)] = memoryEntry{addr: addr, kp: kp}
+	return nil
+}
+
+// ListKeys returns the bare addresses known to the store, matching the contract documented
+// on KeyStore.ListKeys and followed by fileKeyStore.
+func (s *memoryKeyStore) ListKeys() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	addrs := make([]string, 0, len(s.keys))
+	for _, entry := range s.keys {
+		addrs = append(addrs, entry.addr)
+	}
+	return addrs, nil
+}
+
+func (s *memoryKeyStore) DeleteKey(addr, chainType string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, memoryKeyID(addr, chainType))
+	return nil
+}
+
+func (s *memoryKeyStore) ReadOnly() bool {
+	return false
+}
+
+func memoryKeyID(addr, chainType string) string {
+	return chainType + ":" + addr
+}
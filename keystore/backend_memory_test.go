@@ -0,0 +1,21 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import "testing"
+
+func TestMemoryKeyStore_ListKeysReturnsBareAddresses(t *testing.T) {
+	s := newMemoryKeyStore()
+	if err := s.StoreKey("0xabc", "ethereum", nil); err != nil {
+		t.Fatalf("StoreKey returned error: %s", err)
+	}
+
+	addrs, err := s.ListKeys()
+	if err != nil {
+		t.Fatalf("ListKeys returned error: %s", err)
+	}
+	if len(addrs) != 1 || addrs[0] != "0xabc" {
+		t.Fatalf("ListKeys = %v, want [0xabc]", addrs)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import "fmt"
+
+// remoteSignFunc signs msg using key material that never leaves the remote provider.
+type remoteSignFunc func(msg []byte) ([]byte, error)
+
+// remoteKeypair adapts a remote HSM/KMS signing key to the crypto.Keypair interface. The
+// private key never enters process memory, so anything that would require touching it
+// directly (Encode/Decode) is rejected rather than approximated.
+type remoteKeypair struct {
+	provider string
+	address  string
+	keyType  string
+	sign     remoteSignFunc
+}
+
+func (k *remoteKeypair) Sign(msg []byte) ([]byte, error) {
+	return k.sign(msg)
+}
+
+func (k *remoteKeypair) Address() string {
+	return k.address
+}
+
+func (k *remoteKeypair) Type() string {
+	return k.keyType
+}
+
+func (k *remoteKeypair) Encode() []byte {
+	return nil
+}
+
+func (k *remoteKeypair) Decode([]byte) error {
+	return fmt.Errorf("%s: private key material is not accessible, it never leaves the remote provider", k.provider)
+}
+
+func (k *remoteKeypair) DeleteKeyPair() {
+	// No local key material to zero; the key lives in the remote provider.
+}
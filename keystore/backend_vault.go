@@ -0,0 +1,117 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+
+	vaultapi "github.com/hashicorp/vault/api"
+
+	"github.com/ChainSafe/chainbridge-utils/crypto"
+	"github.com/awnumar/memguard"
+)
+
+// vaultKeyStore signs through a HashiCorp Vault transit engine mount, identified by a URL
+// like "vault://vault.example.com:8200/transit/keys/bridge-relayer". The VAULT_TOKEN
+// environment variable (or the rest of the client's usual auth resolution) supplies
+// credentials; the private key never leaves Vault.
+type vaultKeyStore struct {
+	client   *vaultapi.Client
+	keyPath  string
+	signPath string
+}
+
+func newVaultKeyStore(u *url.URL) (*vaultKeyStore, error) {
+	keyPath := strings.TrimPrefix(u.Path, "/")
+	if keyPath == "" {
+		return nil, fmt.Errorf("vault: missing transit key path in URL %s", u.String())
+	}
+	signPath, err := vaultSignPath(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := vaultapi.DefaultConfig()
+	cfg.Address = fmt.Sprintf("https://%s", u.Host)
+	client, err := vaultapi.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("vault: failed to create client: %w", err)
+	}
+	return &vaultKeyStore{client: client, keyPath: keyPath, signPath: signPath}, nil
+}
+
+// vaultSignPath turns a transit key path ("<mount>/keys/<name>") into the path of
+// Vault's sign endpoint ("<mount>/sign/<name>"), per Vault's transit secrets engine API.
+func vaultSignPath(keyPath string) (string, error) {
+	mount, name, ok := strings.Cut(keyPath, "/keys/")
+	if !ok || mount == "" || name == "" {
+		return "", fmt.Errorf("vault: key path %q is not of the form <mount>/keys/<name>", keyPath)
+	}
+	return mount + "/sign/" + name, nil
+}
+
+func (s *vaultKeyStore) GetKey(addr, chainType string) (crypto.Keypair, *memguard.Enclave, error) {
+	kp := &remoteKeypair{
+		provider: "vault",
+		address:  addr,
+		keyType:  keyMapping[chainType],
+		sign: func(msg []byte) ([]byte, error) {
+			secret, err := s.client.Logical().Write(s.signPath, map[string]interface{}{
+				"input":     base64.StdEncoding.EncodeToString(msg),
+				"prehashed": true,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("vault: sign failed: %w", err)
+			}
+			sig, ok := secret.Data["signature"].(string)
+			if !ok {
+				return nil, fmt.Errorf("vault: response missing signature field")
+			}
+			der, err := decodeVaultSignature(sig)
+			if err != nil {
+				return nil, err
+			}
+			// Vault's ecdsa-p256/secp256k1 transit keys wrap a DER-encoded ECDSA signature
+			// with no recovery id, same as AWS/GCP KMS; convert it the same way so every
+			// remote-signing backend honors the "raw, chain-appropriate signature" contract
+			// from KeyStore.GetKey's doc comment.
+			return derEcdsaSigToRSV(der, msg, addr)
+		},
+	}
+	return kp, nil, nil
+}
+
+func (s *vaultKeyStore) StoreKey(addr, chainType string, kp crypto.Keypair) error {
+	return fmt.Errorf("vault: keys must be provisioned in the transit engine out-of-band, StoreKey is unsupported")
+}
+
+func (s *vaultKeyStore) ListKeys() ([]string, error) {
+	return nil, fmt.Errorf("vault: ListKeys is unsupported, list transit keys directly via the Vault API")
+}
+
+func (s *vaultKeyStore) DeleteKey(addr, chainType string) error {
+	_, err := s.client.Logical().Delete(s.keyPath)
+	return err
+}
+
+func (s *vaultKeyStore) ReadOnly() bool {
+	return false
+}
+
+// decodeVaultSignature strips Vault's "vault:v<version>:" envelope from a transit
+// sign response and base64-decodes the remainder into raw signature bytes.
+func decodeVaultSignature(sig string) ([]byte, error) {
+	parts := strings.SplitN(sig, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, fmt.Errorf("vault: unrecognized signature format %q", sig)
+	}
+	raw, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("vault: failed to decode signature: %w", err)
+	}
+	return raw, nil
+}
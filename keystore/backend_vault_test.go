@@ -0,0 +1,60 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestVaultSignPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyPath string
+		want    string
+		wantErr bool
+	}{
+		{name: "ok", keyPath: "transit/keys/bridge-relayer", want: "transit/sign/bridge-relayer"},
+		{name: "nested mount", keyPath: "secret/transit/keys/bridge-relayer", want: "secret/transit/sign/bridge-relayer"},
+		{name: "missing keys segment", keyPath: "transit/bridge-relayer", wantErr: true},
+		{name: "empty name", keyPath: "transit/keys/", wantErr: true},
+		{name: "empty mount", keyPath: "keys/bridge-relayer", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := vaultSignPath(tt.keyPath)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("vaultSignPath returned error: %s", err)
+			}
+			if got != tt.want {
+				t.Fatalf("vaultSignPath(%q) = %q, want %q", tt.keyPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeVaultSignature(t *testing.T) {
+	raw := []byte{0xde, 0xad, 0xbe, 0xef}
+	sig := "vault:v1:" + base64.StdEncoding.EncodeToString(raw)
+
+	got, err := decodeVaultSignature(sig)
+	if err != nil {
+		t.Fatalf("decodeVaultSignature returned error: %s", err)
+	}
+	if string(got) != string(raw) {
+		t.Fatalf("decodeVaultSignature = %x, want %x", got, raw)
+	}
+}
+
+func TestDecodeVaultSignature_Malformed(t *testing.T) {
+	if _, err := decodeVaultSignature("not-a-vault-signature"); err == nil {
+		t.Fatal("expected an error for a malformed signature, got nil")
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// fileStabilityDelay is how long scanAccounts waits between the two size checks it uses to
+// avoid indexing a key file that is still being written.
+const fileStabilityDelay = 50 * time.Millisecond
+
+// scanAccounts walks keydir and returns the accounts found there, keyed by address. A file
+// is only included once its size is observed to be stable across two scans, so a writer
+// that is still streaming a key to disk doesn't get picked up half-written. The single
+// fileStabilityDelay wait below is paid once per scan, not once per file: every ".key" file
+// is size-sampled up front and re-stat'd after one shared sleep, so a directory with many
+// keys doesn't cost fileStabilityDelay-per-file.
+func scanAccounts(keydir string) (map[string]Account, error) {
+	entries, err := ioutil.ReadDir(keydir)
+	if err != nil {
+		return nil, err
+	}
+
+	type candidate struct {
+		path      string
+		firstSize int64
+	}
+	candidates := make([]candidate, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".key") {
+			continue
+		}
+		candidates = append(candidates, candidate{
+			path:      filepath.Join(keydir, entry.Name()),
+			firstSize: entry.Size(),
+		})
+	}
+	if len(candidates) == 0 {
+		return map[string]Account{}, nil
+	}
+
+	time.Sleep(fileStabilityDelay)
+
+	accounts := make(map[string]Account, len(candidates))
+	for _, c := range candidates {
+		if !isFileStable(c.path, c.firstSize) {
+			continue
+		}
+		addr := strings.TrimSuffix(filepath.Base(c.path), ".key")
+		accounts[addr] = Account{
+			Address:   addr,
+			ChainType: chainTypeFromKeyFile(c.path),
+			Path:      c.path,
+		}
+	}
+	return accounts, nil
+}
+
+// isFileStable reports whether path's size still matches firstSize, i.e. whether the file
+// looks like it has finished being written. Callers are expected to have already waited
+// fileStabilityDelay since firstSize was observed.
+func isFileStable(path string, firstSize int64) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return info.Size() == firstSize
+}
+
+// chainTypeFromKeyFile inspects the keystore JSON at path to recover which chain type it
+// was encrypted for, falling back to "" if the file can't be read or parsed.
+func chainTypeFromKeyFile(path string) string {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	for chainType, keyType := range keyMapping {
+		if strings.Contains(string(raw), `"type":"`+keyType+`"`) {
+			return chainType
+		}
+	}
+	return ""
+}
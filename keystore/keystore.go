@@ -27,7 +27,9 @@ package keystore
 import (
 	"crypto/ecdsa"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"sync"
 
 	"github.com/ChainSafe/chainbridge-utils/crypto"
 	"github.com/ChainSafe/chainbridge-utils/hash"
@@ -48,15 +50,39 @@ func KeypairFromAddress(addr, chainType, path string, insecure bool) (crypto.Key
 	if insecure {
 		return insecureKeypairFromAddress(path, chainType)
 	}
-	path = fmt.Sprintf("%s/%s.key", path, addr)
-	// Make sure key exists before prompting password
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return nil, nil, fmt.Errorf("key file not found: %s", path)
+	return KeypairFromAddressWithPassword(addr, chainType, path, interactivePasswordSource{})
+}
+
+// KeypairFromAddressWithPassword is KeypairFromAddress with the password supplied by src
+// instead of an interactive prompt, so automated deployments (systemd units, Kubernetes
+// pods) don't hang waiting on a TTY that will never provide input.
+func KeypairFromAddressWithPassword(addr, chainType, path string, src PasswordSource) (crypto.Keypair, *memguard.Enclave, error) {
+	acct, err := findAccount(path, addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	path = acct.Path
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pswd, err := src.Password(addr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not obtain password for key %s: %w", path, err)
 	}
 
-	var pswd []byte
-	if pswd == nil {
-		pswd = GetPassword(fmt.Sprintf("Enter password for key %s:", path))
+	// Web3 Secret Storage (v3) files are self-describing and use their own KDF/MAC scheme,
+	// so they bypass the iterative password hashing used by the native keystore format.
+	if isV3KeyFile(raw) {
+		kp, err := importV3Bytes(raw, string(pswd))
+		for i := range pswd {
+			pswd[i] = 0
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return kp, nil, nil
 	}
 	hshPwd, salt, err := hash.HashPasswordIteratively(pswd)
 	for i := 0; i < len(pswd); i++ {
@@ -93,6 +119,49 @@ func KeypairFromAddress(addr, chainType, path string, insecure bool) (crypto.Key
 	return kp, key, nil
 }
 
+// findAccount resolves addr under the keystore directory dir. It consults the AccountCache
+// first, but the cache can lag a file that was just created (up to the watch debounce, or
+// simply because the cache for dir was built before the file existed), so a miss falls back
+// to a direct stat of the conventional "<dir>/<addr>.key" path rather than failing outright.
+// A successful fallback also nudges the cache to reload so later lookups hit the index again;
+// that reload runs synchronously on the caller's goroutine and pays one fileStabilityDelay
+// (see file_cache.go), so a cache miss adds roughly that much latency to this call.
+func findAccount(dir, addr string) (Account, error) {
+	ac := cacheForDir(dir)
+	if acct, err := ac.Find(addr); err == nil {
+		return acct, nil
+	}
+
+	path := fmt.Sprintf("%s/%s.key", dir, addr)
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return Account{}, fmt.Errorf("key file not found: %s", path)
+		}
+		return Account{}, err
+	}
+	ac.reload()
+	return Account{Address: addr, ChainType: chainTypeFromKeyFile(path), Path: path}, nil
+}
+
+var (
+	accountCaches   = make(map[string]*AccountCache)
+	accountCachesMu sync.Mutex
+)
+
+// cacheForDir returns the AccountCache for keystore directory dir, creating and starting
+// one (with its fsnotify watcher) the first time dir is seen.
+func cacheForDir(dir string) *AccountCache {
+	accountCachesMu.Lock()
+	defer accountCachesMu.Unlock()
+
+	if ac, ok := accountCaches[dir]; ok {
+		return ac
+	}
+	ac := NewAccountCache(dir)
+	accountCaches[dir] = ac
+	return ac
+}
+
 // BytesToPrivateKey converts a []byte to *ecdsa.PrivateKey
 func BytesToPrivateKey(keyBytes []byte) (*ecdsa.PrivateKey, error) {
 	return secp256k1.ToECDSA(keyBytes)
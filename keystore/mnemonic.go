@@ -0,0 +1,78 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/chainbridge-utils/crypto"
+	"github.com/ChainSafe/chainbridge-utils/crypto/secp256k1"
+	"github.com/ChainSafe/chainbridge-utils/crypto/sr25519"
+	"github.com/tyler-smith/go-bip32"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// ImportMnemonic derives the keypair at BIP44 index index of phrase for chainType
+// ("ethereum" or "substrate"), optionally strengthened with passphrase. This lets bridge
+// operators onboard keys generated by hardware wallets or other BIP39-compatible tooling
+// directly from their recovery phrase.
+//
+// The two chain types are NOT derived the same way: ethereum uses standard secp256k1 BIP32
+// HD derivation over m/44'/60'/0'/0/n, while substrate's sr25519 keys use schnorrkel's own
+// blake2b-based hard-junction scheme over m/44'/354'/0'/0'/n' (the path Polkadot.js and
+// hardware wallets use) -- there is no BIP32 chain code involved on that side at all.
+func ImportMnemonic(phrase, passphrase, chainType string, index uint32) (crypto.Keypair, error) {
+	if !bip39.IsMnemonicValid(phrase) {
+		return nil, fmt.Errorf("invalid mnemonic phrase")
+	}
+	seed := bip39.NewSeed(phrase, passphrase)
+
+	switch chainType {
+	case "ethereum":
+		kp, err := deriveEthereumKeypair(seed, index)
+		if err != nil {
+			return nil, fmt.Errorf("deriving %s: %w", bip44PathString(chainType, index), err)
+		}
+		return kp, nil
+	case "substrate":
+		kp, err := deriveSubstrateKeypair(phrase, passphrase, index)
+		if err != nil {
+			return nil, fmt.Errorf("deriving %s: %w", bip44PathString(chainType, index), err)
+		}
+		return kp, nil
+	default:
+		return nil, fmt.Errorf("unsupported chain type for mnemonic derivation: %s", chainType)
+	}
+}
+
+// deriveEthereumKeypair walks the standard secp256k1 BIP32 path m/44'/60'/0'/0/index.
+func deriveEthereumKeypair(seed []byte, index uint32) (crypto.Keypair, error) {
+	const hardened = bip32.FirstHardenedChild
+	path := []uint32{44 + hardened, 60 + hardened, 0 + hardened, 0, index}
+
+	child, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range path {
+		child, err = child.NewChildKey(segment)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secp256k1.NewKeypairFromPrivateKeyBytes(child.Key)
+}
+
+// bip44PathString renders the derivation path used for chainType/index in the conventional
+// m/44'/.../n notation, for use in error messages.
+func bip44PathString(chainType string, index uint32) string {
+	switch chainType {
+	case "ethereum":
+		return fmt.Sprintf("m/44'/60'/0'/0/%d", index)
+	case "substrate":
+		return fmt.Sprintf("m/44'/354'/0'/0'/%d'", index)
+	default:
+		return "m/44'/?'/0'/0/?"
+	}
+}
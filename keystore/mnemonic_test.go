@@ -0,0 +1,39 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"strings"
+	"testing"
+)
+
+// Hardhat/Ganache's well-known default test mnemonic; account 0 on m/44'/60'/0'/0/0 is a
+// widely published, stable vector for exercising BIP32/BIP44 ethereum derivation.
+const testMnemonic = "test test test test test test test test test test test junk"
+const testMnemonicAddr0 = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+
+func TestImportMnemonic_Ethereum(t *testing.T) {
+	kp, err := ImportMnemonic(testMnemonic, "", "ethereum", 0)
+	if err != nil {
+		t.Fatalf("ImportMnemonic returned error: %s", err)
+	}
+
+	got := strings.ToLower(kp.Address())
+	want := strings.ToLower(testMnemonicAddr0)
+	if got != want {
+		t.Fatalf("derived address %s, want %s", got, want)
+	}
+}
+
+func TestImportMnemonic_InvalidPhrase(t *testing.T) {
+	if _, err := ImportMnemonic("not a valid mnemonic phrase at all", "", "ethereum", 0); err == nil {
+		t.Fatal("expected error for invalid mnemonic phrase, got nil")
+	}
+}
+
+func TestImportMnemonic_UnsupportedChainType(t *testing.T) {
+	if _, err := ImportMnemonic(testMnemonic, "", "bitcoin", 0); err == nil {
+		t.Fatal("expected error for unsupported chain type, got nil")
+	}
+}
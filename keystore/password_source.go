@@ -0,0 +1,120 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// PasswordSource supplies the password used to decrypt the key file for addr.
+// Implementations let KeypairFromAddressWithPassword run without an interactive TTY.
+type PasswordSource interface {
+	Password(addr string) ([]byte, error)
+}
+
+// interactivePasswordSource is the historical behaviour: prompt on the TTY.
+type interactivePasswordSource struct{}
+
+func (interactivePasswordSource) Password(addr string) ([]byte, error) {
+	return GetPassword(fmt.Sprintf("Enter password for key %s:", addr)), nil
+}
+
+// EnvPasswordSource reads the password from the environment, preferring a per-address
+// variable (KEYSTORE_PASSWORD_<ADDR>, address upper-cased) over the blanket EnvPassword
+// (KEYSTORE_PASSWORD) variable so a single process can unlock several distinct keys.
+type EnvPasswordSource struct{}
+
+// NewEnvPasswordSource returns a PasswordSource backed by the process environment.
+func NewEnvPasswordSource() EnvPasswordSource {
+	return EnvPasswordSource{}
+}
+
+func (EnvPasswordSource) Password(addr string) ([]byte, error) {
+	perAddrVar := EnvPassword + "_" + strings.ToUpper(addr)
+	if v, ok := os.LookupEnv(perAddrVar); ok {
+		return []byte(v), nil
+	}
+	if v, ok := os.LookupEnv(EnvPassword); ok {
+		return []byte(v), nil
+	}
+	return nil, fmt.Errorf("neither %s nor %s is set", perAddrVar, EnvPassword)
+}
+
+// FilePasswordSource reads the password from a file (e.g. a Kubernetes secret mount),
+// trimming a single trailing newline if present.
+type FilePasswordSource struct {
+	Path string
+}
+
+// NewFilePasswordSource returns a PasswordSource that reads the password from path.
+func NewFilePasswordSource(path string) FilePasswordSource {
+	return FilePasswordSource{Path: path}
+}
+
+func (s FilePasswordSource) Password(addr string) ([]byte, error) {
+	data, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		return nil, err
+	}
+	return trimTrailingNewline(data), nil
+}
+
+// StdinPasswordSource reads successive lines of password from stdin, for use when it has
+// been piped rather than attached to a terminal (one line per key, in unlock order). It
+// keeps a single long-lived *bufio.Reader across calls: a fresh bufio.Reader per call would
+// read ahead into its own buffer and discard it, losing input meant for the next address.
+type StdinPasswordSource struct {
+	mu     sync.Mutex
+	reader *bufio.Reader
+}
+
+// NewStdinPasswordSource returns a PasswordSource that reads one line per call from stdin.
+func NewStdinPasswordSource() *StdinPasswordSource {
+	return &StdinPasswordSource{}
+}
+
+func (s *StdinPasswordSource) Password(addr string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reader == nil {
+		s.reader = bufio.NewReader(os.Stdin)
+	}
+	line, err := s.reader.ReadString('\n')
+	if err != nil && line == "" {
+		return nil, err
+	}
+	return trimTrailingNewline([]byte(line)), nil
+}
+
+// CommandPasswordSource runs an external command through the shell and uses its stdout as
+// the password, e.g. `--password-command "vault kv get -field=password secret/relayer"`.
+type CommandPasswordSource struct {
+	Command string
+}
+
+// NewCommandPasswordSource returns a PasswordSource backed by the given shell command.
+func NewCommandPasswordSource(command string) CommandPasswordSource {
+	return CommandPasswordSource{Command: command}
+}
+
+func (s CommandPasswordSource) Password(addr string) ([]byte, error) {
+	cmd := exec.Command("sh", "-c", s.Command)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("password command failed: %w", err)
+	}
+	return trimTrailingNewline(out), nil
+}
+
+func trimTrailingNewline(data []byte) []byte {
+	data = bytes.TrimSuffix(data, []byte("\n"))
+	return bytes.TrimSuffix(data, []byte("\r"))
+}
@@ -0,0 +1,126 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPasswordSources(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "password")
+	if err := ioutil.WriteFile(tmpFile, []byte("file-password\n"), 0600); err != nil {
+		t.Fatalf("could not write temp password file: %s", err)
+	}
+
+	tests := []struct {
+		name   string
+		src    PasswordSource
+		setup  func(t *testing.T)
+		addr   string
+		want   string
+		wantOk bool
+	}{
+		{
+			name: "env per-address",
+			src:  NewEnvPasswordSource(),
+			setup: func(t *testing.T) {
+				t.Setenv("KEYSTORE_PASSWORD_0XABC", "addr-password")
+				t.Setenv(EnvPassword, "blanket-password")
+			},
+			addr:   "0xabc",
+			want:   "addr-password",
+			wantOk: true,
+		},
+		{
+			name: "env blanket fallback",
+			src:  NewEnvPasswordSource(),
+			setup: func(t *testing.T) {
+				t.Setenv(EnvPassword, "blanket-password")
+			},
+			addr:   "0xdef",
+			want:   "blanket-password",
+			wantOk: true,
+		},
+		{
+			name:   "env unset",
+			src:    NewEnvPasswordSource(),
+			setup:  func(t *testing.T) {},
+			addr:   "0xnope",
+			wantOk: false,
+		},
+		{
+			name:   "file",
+			src:    NewFilePasswordSource(tmpFile),
+			setup:  func(t *testing.T) {},
+			addr:   "0xabc",
+			want:   "file-password",
+			wantOk: true,
+		},
+		{
+			name: "command",
+			src:  NewCommandPasswordSource("echo command-password"),
+			setup: func(t *testing.T) {
+				if _, err := os.Stat("/bin/sh"); err != nil {
+					t.Skip("/bin/sh not available")
+				}
+			},
+			addr:   "0xabc",
+			want:   "command-password",
+			wantOk: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup(t)
+			got, err := tt.src.Password(tt.addr)
+			if tt.wantOk && err != nil {
+				t.Fatalf("Password returned error: %s", err)
+			}
+			if !tt.wantOk && err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if tt.wantOk && string(got) != tt.want {
+				t.Fatalf("Password = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStdinPasswordSourceSequentialReads(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("could not create pipe: %s", err)
+	}
+	defer r.Close()
+
+	origStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = origStdin }()
+
+	go func() {
+		defer w.Close()
+		w.WriteString("first-password\nsecond-password\n")
+	}()
+
+	src := NewStdinPasswordSource()
+	got1, err := src.Password("0xabc")
+	if err != nil {
+		t.Fatalf("first Password returned error: %s", err)
+	}
+	if string(got1) != "first-password" {
+		t.Fatalf("first Password = %q, want %q", got1, "first-password")
+	}
+
+	got2, err := src.Password("0xdef")
+	if err != nil {
+		t.Fatalf("second Password returned error: %s", err)
+	}
+	if string(got2) != "second-password" {
+		t.Fatalf("second Password = %q, want %q", got2, "second-password")
+	}
+}
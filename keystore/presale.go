@@ -0,0 +1,103 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ChainSafe/chainbridge-utils/crypto"
+	"github.com/ChainSafe/chainbridge-utils/crypto/secp256k1"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/sha3"
+)
+
+// presaleWalletJSON is the legacy format produced by the 2014 Ethereum presale, predating
+// Web3 Secret Storage entirely: a single AES-CBC encrypted seed alongside the address it
+// was sold for.
+type presaleWalletJSON struct {
+	EncSeed string `json:"encseed"`
+	EthAddr string `json:"ethaddr"`
+	Email   string `json:"email"`
+}
+
+// ImportPresale reads a legacy Ethereum presale wallet file at path and decrypts it with
+// password, returning the recovered secp256k1 keypair. It lets operators onboard keys that
+// predate the v3 keystore format entirely without a separate conversion tool.
+func ImportPresale(path, password string) (crypto.Keypair, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var wallet presaleWalletJSON
+	if err := json.Unmarshal(data, &wallet); err != nil {
+		return nil, err
+	}
+
+	encSeed, err := hex.DecodeString(wallet.EncSeed)
+	if err != nil {
+		return nil, fmt.Errorf("presale: invalid encseed: %w", err)
+	}
+	if len(encSeed) < aes.BlockSize {
+		return nil, fmt.Errorf("presale: encseed too short")
+	}
+	iv, cipherText := encSeed[:aes.BlockSize], encSeed[aes.BlockSize:]
+
+	// The presale format derives its AES key from the password using itself as the salt.
+	key := pbkdf2.Key([]byte(password), []byte(password), 2000, 16, sha256.New)
+	seed, err := aesCBCDecrypt(key, cipherText, iv)
+	if err != nil {
+		return nil, fmt.Errorf("presale: could not decrypt, wrong password?: %w", err)
+	}
+	seed = pkcs7Unpad(seed)
+
+	firstHash := sha3.NewLegacyKeccak256()
+	firstHash.Write(seed)
+	secondHash := sha3.NewLegacyKeccak256()
+	secondHash.Write(firstHash.Sum(nil))
+	privateKeyBytes := secondHash.Sum(nil)
+
+	kp, err := secp256k1.NewKeypairFromPrivateKeyBytes(privateKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(strings.TrimPrefix(kp.Address(), "0x"), strings.TrimPrefix(wallet.EthAddr, "0x")) {
+		return nil, fmt.Errorf("presale: decrypted key does not match ethaddr %s", wallet.EthAddr)
+	}
+	return kp, nil
+}
+
+// aesCBCDecrypt decrypts data (whose length must be a multiple of the AES block size) with
+// key and iv using AES-CBC.
+func aesCBCDecrypt(key, data, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("ciphertext is not a multiple of the block size")
+	}
+	out := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(out, data)
+	return out, nil
+}
+
+// pkcs7Unpad strips PKCS#7 padding from data, returning data unchanged if it doesn't look padded.
+func pkcs7Unpad(data []byte) []byte {
+	if len(data) == 0 {
+		return data
+	}
+	padLen := int(data[len(data)-1])
+	if padLen == 0 || padLen > len(data) {
+		return data
+	}
+	return data[:len(data)-padLen]
+}
@@ -0,0 +1,63 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testPresaleEncSeed, testPresalePassword and testPresaleEthAddr are a self-generated known
+// vector (PBKDF2-SHA256/2000 over the password as its own salt, AES-128-CBC, then
+// keccak256(keccak256(seed)) as the private key) so a regression in the decrypt pipeline
+// trips ImportPresale's own ethaddr check rather than silently returning the wrong key.
+const (
+	testPresaleEncSeed  = "000102030405060708090a0b0c0d0e0fe457c0c54ca478daa50f4c76c3cf1787afedcd0e8e3de48863e45bfecf98b8880e98ff2f0573d4fc8795274b8017977f"
+	testPresalePassword = "testpresalepassword"
+	testPresaleEthAddr  = "0x0125b76c87e354deebf09860da4b24440e73d8d3"
+)
+
+func writePresaleWallet(t *testing.T, encSeed, ethAddr string) string {
+	t.Helper()
+	wallet := presaleWalletJSON{
+		EncSeed: encSeed,
+		EthAddr: ethAddr,
+		Email:   "presale@example.com",
+	}
+	data, err := json.Marshal(wallet)
+	if err != nil {
+		t.Fatalf("could not marshal presale wallet: %s", err)
+	}
+	path := filepath.Join(t.TempDir(), "presale.json")
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("could not write presale wallet: %s", err)
+	}
+	return path
+}
+
+func TestImportPresale(t *testing.T) {
+	path := writePresaleWallet(t, testPresaleEncSeed, testPresaleEthAddr)
+
+	kp, err := ImportPresale(path, testPresalePassword)
+	if err != nil {
+		t.Fatalf("ImportPresale returned error: %s", err)
+	}
+
+	got := strings.ToLower(kp.Address())
+	want := strings.ToLower(testPresaleEthAddr)
+	if got != want {
+		t.Fatalf("derived address %s, want %s", got, want)
+	}
+}
+
+func TestImportPresale_WrongPassword(t *testing.T) {
+	path := writePresaleWallet(t, testPresaleEncSeed, testPresaleEthAddr)
+
+	if _, err := ImportPresale(path, "not the right password"); err == nil {
+		t.Fatal("expected an error for the wrong password, got nil")
+	}
+}
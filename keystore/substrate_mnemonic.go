@@ -0,0 +1,72 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ChainSafe/chainbridge-utils/crypto"
+	"github.com/ChainSafe/chainbridge-utils/crypto/sr25519"
+	schnorrkel "github.com/ChainSafe/go-schnorrkel"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// substrateHardJunctions is the hardened form of m/44'/354'/0'/0'/index' used by
+// Polkadot.js and hardware wallets, expressed as the u32 indices that get chain-coded below.
+var substrateHardJunctions = []uint32{44, 354, 0, 0}
+
+// deriveSubstrateKeypair recovers the sr25519 keypair a Polkadot.js/hardware wallet would
+// derive from phrase/passphrase at account index. Unlike ethereum, substrate does not use
+// BIP32 at all: the mini-secret seed comes from substrate's own PBKDF2-over-entropy scheme
+// (not the BIP39 seed used for ethereum), and each path segment is a schnorrkel "hard
+// junction" derived via blake2b/merlin transcripts rather than an ECDSA chain code. Each hard
+// derivation step takes the chain code produced by the previous one (the master key starts
+// from the zero chain code), exactly as go-schnorrkel's HardDeriveMiniSecretKey threads it.
+func deriveSubstrateKeypair(phrase, passphrase string, index uint32) (crypto.Keypair, error) {
+	entropy, err := bip39.EntropyFromMnemonic(phrase)
+	if err != nil {
+		return nil, err
+	}
+
+	msk := schnorrkel.NewMiniSecretKey(substrateMiniSecretSeed(entropy, passphrase))
+
+	junctions := append(append([]uint32{}, substrateHardJunctions...), index)
+	var cc [32]byte
+	for _, j := range junctions {
+		msk, cc, err = msk.HardDeriveMiniSecretKey(substrateJunctionBytes(j), cc)
+		if err != nil {
+			return nil, fmt.Errorf("deriving hard junction %d: %w", j, err)
+		}
+	}
+
+	secretKey := msk.ExpandEd25519()
+	return sr25519.NewKeypairFromSeed(secretKey.Encode())
+}
+
+// substrateJunctionBytes encodes a hardened numeric path segment the same way substrate's
+// DeriveJunction::hard(index) does: the little-endian index bytes, zero-padded to 32 bytes.
+// This is the "i" input to HardDeriveMiniSecretKey, not a chain code in its own right — the
+// chain code that actually carries forward between levels is the one the call returns.
+func substrateJunctionBytes(index uint32) []byte {
+	var b [32]byte
+	binary.LittleEndian.PutUint32(b[:4], index)
+	return b[:]
+}
+
+// substrateMiniSecretSeed implements substrate-bip39's entropy-to-seed step:
+// pbkdf2(entropy, "mnemonic"+passphrase, 2048, HMAC-SHA512), the full 64-byte digest
+// go-schnorrkel's NewMiniSecretKey expects. This is deliberately distinct from the BIP39 seed
+// (which PBKDF2s the mnemonic sentence, not its entropy) so that substrate and ethereum
+// addresses from the same phrase differ.
+func substrateMiniSecretSeed(entropy []byte, passphrase string) [64]byte {
+	salt := "mnemonic" + passphrase
+	derived := pbkdf2.Key(entropy, []byte(salt), 2048, 64, sha512.New)
+
+	var raw [64]byte
+	copy(raw[:], derived)
+	return raw
+}
@@ -0,0 +1,156 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/chainbridge-utils/crypto"
+	"github.com/awnumar/memguard"
+)
+
+// unlockedEntry holds a decrypted keypair and the timer that will lock it again. mu guards
+// against destroying the key material while a signature is in flight: SignWith holds a read
+// lock for the duration of Sign, and destroyEntry takes the write lock so it blocks until any
+// in-flight signs finish before zeroing the enclave and deleting the keypair.
+type unlockedEntry struct {
+	mu      sync.RWMutex
+	kp      crypto.Keypair
+	enclave *memguard.Enclave
+	timer   *time.Timer
+}
+
+// UnlockedKeyring keeps decrypted keypairs from a single keystore directory in memory,
+// each behind a configurable TTL, mirroring the Unlock/TimedUnlock pattern from
+// go-ethereum's keystore.KeyStore. It lets a relayer decrypt its keys once at startup
+// instead of re-prompting for a password on every signature.
+type UnlockedKeyring struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]*unlockedEntry
+}
+
+// NewUnlockedKeyring creates an UnlockedKeyring backed by the keystore directory at path.
+func NewUnlockedKeyring(path string) *UnlockedKeyring {
+	return &UnlockedKeyring{
+		path:    path,
+		entries: make(map[string]*unlockedEntry),
+	}
+}
+
+// Unlock decrypts the key for addr with password and keeps it available to SignWith until
+// timeout elapses, at which point it is automatically locked and its memory zeroed. A
+// timeout of 0 means the key stays unlocked until Lock is called explicitly.
+func (k *UnlockedKeyring) Unlock(addr, password string, timeout time.Duration) error {
+	acct, err := findAccount(k.path, addr)
+	if err != nil {
+		return err
+	}
+
+	kp, enclave, err := KeypairFromAddressWithPassword(addr, acct.ChainType, k.path, staticPasswordSource(password))
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	// Re-unlocking an already-unlocked address must not leak the previous decrypted key:
+	// destroy it the same way Lock would before installing the new entry.
+	if existing, ok := k.entries[addr]; ok {
+		destroyEntry(existing)
+	}
+
+	entry := &unlockedEntry{kp: kp, enclave: enclave}
+	if timeout > 0 {
+		entry.timer = time.AfterFunc(timeout, func() {
+			k.mu.Lock()
+			defer k.mu.Unlock()
+			// Only lock this entry: a timer that fired while a re-Unlock replaced it must not
+			// lock the replacement.
+			if k.entries[addr] == entry {
+				destroyEntry(entry)
+				delete(k.entries, addr)
+			}
+		})
+	}
+	k.entries[addr] = entry
+	return nil
+}
+
+// Lock destroys the decrypted key material for addr, if it is currently unlocked.
+func (k *UnlockedKeyring) Lock(addr string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	entry, ok := k.entries[addr]
+	if !ok {
+		return
+	}
+	destroyEntry(entry)
+	delete(k.entries, addr)
+}
+
+// destroyEntry zeroes and releases the key material held by entry: its enclave (if any)
+// and the keypair itself. Callers must already hold k.mu. It waits for any signature in
+// flight on entry to complete before destroying the key material out from under it.
+func destroyEntry(entry *unlockedEntry) {
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.timer != nil {
+		entry.timer.Stop()
+	}
+	if entry.enclave != nil {
+		if buf, err := entry.enclave.Open(); err == nil {
+			buf.Destroy()
+		}
+	}
+	entry.kp.DeleteKeyPair()
+}
+
+// SignWith signs msg with the currently unlocked key for addr. It acquires the entry's read
+// lock before releasing k.mu, not after: a concurrent Lock (explicit or TTL-triggered) also
+// takes k.mu before it can destroy the entry, so taking both locks in the same critical
+// section closes the gap where Lock could run (and zero the key material) between this
+// method reading the map and it actually guarding the entry.
+func (k *UnlockedKeyring) SignWith(addr string, msg []byte) ([]byte, error) {
+	k.mu.Lock()
+	entry, ok := k.entries[addr]
+	if ok {
+		entry.mu.RLock()
+	}
+	k.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("key %s is locked", addr)
+	}
+	defer entry.mu.RUnlock()
+
+	return entry.kp.Sign(msg)
+}
+
+// UnlockAll decrypts every address in passwords against the keystore directory at path in
+// one pass, returning a keyring with all of them unlocked with no expiry. This is meant for
+// relayer startup, where every chain key is needed up front and should never trigger another
+// interactive prompt.
+func UnlockAll(path string, passwords map[string]string) (*UnlockedKeyring, error) {
+	kr := NewUnlockedKeyring(path)
+	for addr, password := range passwords {
+		if err := kr.Unlock(addr, password, 0); err != nil {
+			return nil, fmt.Errorf("unlocking %s: %w", addr, err)
+		}
+	}
+	return kr, nil
+}
+
+// staticPasswordSource is a PasswordSource that always returns the same password, used to
+// adapt UnlockedKeyring's already-known password onto KeypairFromAddressWithPassword.
+type staticPasswordSource string
+
+func (s staticPasswordSource) Password(addr string) ([]byte, error) {
+	return []byte(s), nil
+}
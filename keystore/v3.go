@@ -0,0 +1,226 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ChainSafe/chainbridge-utils/crypto"
+	"github.com/ChainSafe/chainbridge-utils/crypto/secp256k1"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+)
+
+// v3Version is the only keystore schema version this package knows how to read/write.
+const v3Version = 3
+
+// v3CipherParams holds the IV used by aes-128-ctr.
+type v3CipherParams struct {
+	IV string `json:"iv"`
+}
+
+// v3KDFParams holds the union of the fields used by the "scrypt" and "pbkdf2" KDFs.
+// Unused fields are simply left at their zero value depending on which KDF is selected.
+type v3KDFParams struct {
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+
+	// scrypt
+	N int `json:"n,omitempty"`
+	R int `json:"r,omitempty"`
+	P int `json:"p,omitempty"`
+
+	// pbkdf2
+	C    int    `json:"c,omitempty"`
+	PRF  string `json:"prf,omitempty"`
+}
+
+// v3CryptoJSON is the "crypto" object of a Web3 Secret Storage v3 file.
+type v3CryptoJSON struct {
+	Cipher       string          `json:"cipher"`
+	CipherText   string          `json:"ciphertext"`
+	CipherParams v3CipherParams  `json:"cipherparams"`
+	KDF          string          `json:"kdf"`
+	KDFParams    v3KDFParams     `json:"kdfparams"`
+	MAC          string          `json:"mac"`
+}
+
+// v3EncryptedKeyJSON is the on-disk representation of a go-ethereum/MetaMask style keystore file.
+type v3EncryptedKeyJSON struct {
+	Address string       `json:"address"`
+	Crypto  v3CryptoJSON `json:"crypto"`
+	ID      string       `json:"id"`
+	Version int          `json:"version"`
+}
+
+// ImportV3 reads the Web3 Secret Storage v3 file at path, decrypts it with password and
+// returns the recovered secp256k1 keypair. This is the format used by go-ethereum and
+// MetaMask, which lets bridge operators reuse keys generated outside of ChainBridge.
+func ImportV3(path, password string) (crypto.Keypair, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return importV3Bytes(data, password)
+}
+
+// isV3KeyFile reports whether data looks like a Web3 Secret Storage v3 JSON file.
+func isV3KeyFile(data []byte) bool {
+	var probe struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return false
+	}
+	return probe.Version == v3Version
+}
+
+func importV3Bytes(data []byte, password string) (crypto.Keypair, error) {
+	var keyJSON v3EncryptedKeyJSON
+	if err := json.Unmarshal(data, &keyJSON); err != nil {
+		return nil, err
+	}
+	if keyJSON.Version != v3Version {
+		return nil, fmt.Errorf("unsupported keystore version: %d", keyJSON.Version)
+	}
+
+	dk, err := deriveV3Key([]byte(password), keyJSON.Crypto.KDF, keyJSON.Crypto.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := hex.DecodeString(keyJSON.Crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	mac, err := hex.DecodeString(keyJSON.Crypto.MAC)
+	if err != nil {
+		return nil, err
+	}
+	calculatedMAC := sha3.NewLegacyKeccak256()
+	calculatedMAC.Write(dk[16:32])
+	calculatedMAC.Write(cipherText)
+	if subtle.ConstantTimeCompare(calculatedMAC.Sum(nil), mac) != 1 {
+		return nil, fmt.Errorf("could not decrypt key with given password")
+	}
+
+	if keyJSON.Crypto.Cipher != "aes-128-ctr" {
+		return nil, fmt.Errorf("unsupported cipher: %s", keyJSON.Crypto.Cipher)
+	}
+	iv, err := hex.DecodeString(keyJSON.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, err
+	}
+	privateKeyBytes, err := aesCTRXOR(dk[:16], cipherText, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	return secp256k1.NewKeypairFromPrivateKeyBytes(privateKeyBytes)
+}
+
+// ExportV3 encrypts kp with password using scrypt and aes-128-ctr, and returns the resulting
+// Web3 Secret Storage v3 JSON so it can be written out and consumed by go-ethereum or MetaMask.
+func ExportV3(kp crypto.Keypair, password string, scryptN, scryptP int) ([]byte, error) {
+	sk, ok := kp.(*secp256k1.Keypair)
+	if !ok {
+		return nil, fmt.Errorf("v3 keystore export is only supported for secp256k1 keys")
+	}
+	privateKeyBytes := sk.Encode()
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	dk, err := scrypt.Key([]byte(password), salt, scryptN, 8, scryptP, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+	cipherText, err := aesCTRXOR(dk[:16], privateKeyBytes, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := sha3.NewLegacyKeccak256()
+	mac.Write(dk[16:32])
+	mac.Write(cipherText)
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	keyJSON := v3EncryptedKeyJSON{
+		Address: sk.Address(),
+		Crypto: v3CryptoJSON{
+			Cipher:     "aes-128-ctr",
+			CipherText: hex.EncodeToString(cipherText),
+			CipherParams: v3CipherParams{
+				IV: hex.EncodeToString(iv),
+			},
+			KDF: "scrypt",
+			KDFParams: v3KDFParams{
+				DKLen: 32,
+				Salt:  hex.EncodeToString(salt),
+				N:     scryptN,
+				R:     8,
+				P:     scryptP,
+			},
+			MAC: hex.EncodeToString(mac.Sum(nil)),
+		},
+		ID:      id.String(),
+		Version: v3Version,
+	}
+
+	return json.Marshal(keyJSON)
+}
+
+// deriveV3Key derives the 32-byte DK used to decrypt/authenticate a v3 keystore file,
+// dispatching on the "kdf" field as defined by the Web3 Secret Storage spec.
+func deriveV3Key(password []byte, kdf string, params v3KDFParams) ([]byte, error) {
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kdf {
+	case "scrypt":
+		return scrypt.Key(password, salt, params.N, params.R, params.P, params.DKLen)
+	case "pbkdf2":
+		if params.PRF != "hmac-sha256" {
+			return nil, fmt.Errorf("unsupported pbkdf2 prf: %s", params.PRF)
+		}
+		return pbkdf2.Key(password, salt, params.C, params.DKLen, sha256.New), nil
+	default:
+		return nil, fmt.Errorf("unsupported kdf: %s", kdf)
+	}
+}
+
+// aesCTRXOR encrypts or decrypts (CTR mode is symmetric) data with key and iv.
+func aesCTRXOR(key, data, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	stream := cipher.NewCTR(block, iv)
+	out := make([]byte, len(data))
+	stream.XORKeyStream(out, data)
+	return out, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChainSafe/chainbridge-utils/crypto/secp256k1"
+)
+
+// testPrivateKeyBytes is an arbitrary, non-secret 32-byte scalar used only to exercise the
+// v3 encrypt/decrypt round trip.
+var testPrivateKeyBytes = []byte{
+	0x4c, 0x0a, 0x4c, 0xd8, 0x11, 0x5e, 0x77, 0xb8,
+	0x59, 0x62, 0x53, 0x7d, 0x35, 0x0a, 0x98, 0x1d,
+	0xb9, 0x3e, 0x9c, 0x27, 0x0a, 0x63, 0x1e, 0x50,
+	0x9f, 0x03, 0x45, 0x6c, 0xd6, 0xd0, 0x8a, 0x01,
+}
+
+// Low scrypt cost parameters so the test runs quickly; production callers should use the
+// go-ethereum defaults (N=1<<18 or higher).
+const (
+	testScryptN = 1 << 12
+	testScryptP = 1
+)
+
+func TestV3ExportImportRoundTrip(t *testing.T) {
+	kp, err := secp256k1.NewKeypairFromPrivateKeyBytes(testPrivateKeyBytes)
+	if err != nil {
+		t.Fatalf("could not build test keypair: %s", err)
+	}
+
+	data, err := ExportV3(kp, "correct horse battery staple", testScryptN, testScryptP)
+	if err != nil {
+		t.Fatalf("ExportV3 returned error: %s", err)
+	}
+
+	if !isV3KeyFile(data) {
+		t.Fatal("ExportV3 output was not recognized as a v3 key file")
+	}
+
+	imported, err := importV3Bytes(data, "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("importV3Bytes returned error: %s", err)
+	}
+	if !strings.EqualFold(imported.Address(), kp.Address()) {
+		t.Fatalf("round-tripped address = %s, want %s", imported.Address(), kp.Address())
+	}
+}
+
+func TestV3ImportWrongPassword(t *testing.T) {
+	kp, err := secp256k1.NewKeypairFromPrivateKeyBytes(testPrivateKeyBytes)
+	if err != nil {
+		t.Fatalf("could not build test keypair: %s", err)
+	}
+
+	data, err := ExportV3(kp, "correct horse battery staple", testScryptN, testScryptP)
+	if err != nil {
+		t.Fatalf("ExportV3 returned error: %s", err)
+	}
+
+	if _, err := importV3Bytes(data, "wrong password"); err == nil {
+		t.Fatal("expected an error when decrypting with the wrong password, got nil")
+	}
+}
+
+func TestIsV3KeyFile(t *testing.T) {
+	if isV3KeyFile([]byte(`{"type":"secp256k1"}`)) {
+		t.Fatal("native keystore format should not be recognized as v3")
+	}
+	if !isV3KeyFile([]byte(`{"version":3}`)) {
+		t.Fatal("expected version:3 to be recognized as a v3 key file")
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long the watcher waits after the last filesystem event before
+// triggering a rescan, so a burst of writes to the same file only reloads the cache once.
+const watchDebounce = 500 * time.Millisecond
+
+// watcher reacts to fsnotify events on an AccountCache's keystore directory and triggers a
+// debounced reload of the cache.
+type watcher struct {
+	ac   *AccountCache
+	fs   *fsnotify.Watcher
+	quit chan struct{}
+}
+
+func newWatcher(ac *AccountCache) *watcher {
+	fs, err := fsnotify.NewWatcher()
+	if err != nil {
+		// Without a working watcher the cache still works, it just won't pick up
+		// changes on its own; only a findAccount miss that finds the key file reloads it.
+		return &watcher{ac: ac, quit: make(chan struct{})}
+	}
+	if err := fs.Add(ac.keydir); err != nil {
+		fs.Close()
+		return &watcher{ac: ac, quit: make(chan struct{})}
+	}
+	return &watcher{ac: ac, fs: fs, quit: make(chan struct{})}
+}
+
+func (w *watcher) start() {
+	if w.fs == nil {
+		return
+	}
+	go w.loop()
+}
+
+func (w *watcher) loop() {
+	var debounce *time.Timer
+
+	for {
+		select {
+		case _, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			if debounce == nil {
+				debounce = time.AfterFunc(watchDebounce, w.ac.reload)
+			} else {
+				debounce.Reset(watchDebounce)
+			}
+		case <-w.fs.Errors:
+			// Errors are non-fatal; the next successful event still triggers a reload.
+		case <-w.quit:
+			if debounce != nil {
+				debounce.Stop()
+			}
+			return
+		}
+	}
+}
+
+func (w *watcher) close() {
+	close(w.quit)
+	if w.fs != nil {
+		w.fs.Close()
+	}
+}